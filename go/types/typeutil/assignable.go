@@ -0,0 +1,42 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+// This file defines utilities for assignability queries.
+
+import "go/types"
+
+// FilterAssignable returns the elements of candidates that are
+// assignable to target, in their original order.
+//
+// It is intended for completion engines that rank in-scope values by
+// whether they may be passed as an argument of type target.
+func FilterAssignable(target types.Type, candidates []types.Type) []types.Type {
+	var result []types.Type
+	for _, T := range candidates {
+		if types.AssignableTo(T, target) {
+			result = append(result, T)
+		}
+	}
+	return result
+}
+
+// AssignableToEmpty reports whether a value of type T may be assigned to
+// a variable of type interface{}, for example passed as an argument of
+// a ...interface{} parameter such as that of fmt.Println.
+//
+// This holds for every type except tuples, such as the empty tuple of a
+// call of a function without results, and invalid types.
+func AssignableToEmpty(T types.Type) bool {
+	switch T := T.(type) {
+	case *types.Tuple:
+		return false
+	case *types.Basic:
+		if T.Kind() == types.Invalid {
+			return false
+		}
+	}
+	return types.AssignableTo(T, types.NewInterfaceType(nil, nil))
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import (
+	"fmt"
+	"go/types"
+	"testing"
+)
+
+const assignableSrc = `package p
+
+type Stringer interface{ String() string }
+
+type (
+	T    int
+	U    string
+	Ints []int
+)
+
+func (T) String() string  { return "" }
+func (*U) String() string { return "" }
+`
+
+func TestFilterAssignable(t *testing.T) {
+	pkg := typecheck(t, assignableSrc)
+	var candidates []types.Type
+	for _, name := range []string{"T", "U", "Ints", "int", "string"} {
+		candidates = append(candidates, lookupType(t, pkg, name))
+	}
+	candidates = append(candidates,
+		types.NewSlice(types.Typ[types.Int]),
+		types.NewPointer(lookupType(t, pkg, "U")))
+
+	for _, test := range []struct {
+		target types.Type
+		want   string
+	}{
+		{types.NewInterfaceType(nil, nil), "[p.T p.U p.Ints int string []int *p.U]"},
+		{lookupType(t, pkg, "Stringer"), "[p.T *p.U]"},
+		{lookupType(t, pkg, "Ints"), "[p.Ints []int]"},
+		{types.Typ[types.Int], "[int]"},
+		{types.Typ[types.Bool], "[]"},
+	} {
+		var got []string
+		for _, T := range FilterAssignable(test.target, candidates) {
+			got = append(got, T.String())
+		}
+		if s := fmt.Sprint(got); s != test.want {
+			t.Errorf("FilterAssignable(%s) = %s, want %s", test.target, s, test.want)
+		}
+	}
+}
+
+func TestAssignableToEmpty(t *testing.T) {
+	pkg := typecheck(t, assignableSrc)
+	two := types.NewTuple(
+		types.NewVar(0, nil, "", types.Typ[types.Int]),
+		types.NewVar(0, nil, "", types.Typ[types.Int]))
+	for _, test := range []struct {
+		T    types.Type
+		want bool
+	}{
+		{types.Typ[types.Int], true},
+		{types.Typ[types.UntypedString], true},
+		{types.Typ[types.UntypedNil], true},
+		{lookupType(t, pkg, "T"), true},
+		{lookupType(t, pkg, "Stringer"), true},
+		{types.NewPointer(lookupType(t, pkg, "U")), true},
+		{types.NewTuple(), false}, // result of a call of func()
+		{two, false},
+		{types.Typ[types.Invalid], false},
+	} {
+		if got := AssignableToEmpty(test.T); got != test.want {
+			t.Errorf("AssignableToEmpty(%s) = %t, want %t", test.T, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import "go/types"
+
+// UniverseBuiltins returns the predeclared built-in functions of the
+// universe scope, such as len and append, sorted by name.
+//
+// The set depends on the version of go/types in use. Functions of
+// package unsafe are not included.
+func UniverseBuiltins() []*types.Builtin {
+	var builtins []*types.Builtin
+	for _, name := range types.Universe.Names() {
+		if b, ok := types.Universe.Lookup(name).(*types.Builtin); ok {
+			builtins = append(builtins, b)
+		}
+	}
+	return builtins
+}
+
+// AppendElemType returns the element type of the slice type S, against
+// which the variadic arguments of append(s, x...) are checked for a
+// value s of type S. It reports false if S is not a slice type.
+//
+// As a special case, append also accepts a string spread as the second
+// argument, as in append(b, "x"...), when S is assignable to []byte.
+// AppendElemType does not check for this case; callers may detect it by
+// testing whether the returned element type is identical to byte.
+func AppendElemType(S types.Type) (types.Type, bool) {
+	if s, ok := S.Underlying().(*types.Slice); ok {
+		return s.Elem(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestUniverseBuiltins(t *testing.T) {
+	got := make(map[string]bool)
+	for _, b := range UniverseBuiltins() {
+		got[b.Name()] = true
+	}
+	for _, name := range []string{
+		"append", "cap", "close", "complex", "copy", "delete", "imag",
+		"len", "make", "new", "panic", "print", "println", "real", "recover",
+	} {
+		if !got[name] {
+			t.Errorf("UniverseBuiltins() lacks %s", name)
+		}
+	}
+	for _, name := range []string{"Sizeof", "int", "nil", "true"} {
+		if got[name] {
+			t.Errorf("UniverseBuiltins() unexpectedly contains %s", name)
+		}
+	}
+}
+
+func TestAppendElemType(t *testing.T) {
+	pkg := typecheck(t, `package p
+
+type (
+	Ints  []int
+	Array [3]int
+)
+
+var bytes []byte
+`)
+	for _, test := range []struct {
+		S      types.Type
+		want   string
+		wantOk bool
+	}{
+		{types.NewSlice(types.Typ[types.Int]), "int", true},
+		{lookupType(t, pkg, "bytes"), "byte", true},
+		{lookupType(t, pkg, "Ints"), "int", true},
+		{lookupType(t, pkg, "Array"), "", false},
+		{types.Typ[types.String], "", false},
+	} {
+		elem, ok := AppendElemType(test.S)
+		var got string
+		if elem != nil {
+			got = elem.String()
+		}
+		if got != test.want || ok != test.wantOk {
+			t.Errorf("AppendElemType(%s) = (%s, %t), want (%s, %t)",
+				test.S, got, ok, test.want, test.wantOk)
+		}
+	}
+}
+
+// TestAppendElemTypeString checks that a string spread is valid in a
+// call to append exactly when the element type is identical to byte.
+func TestAppendElemTypeString(t *testing.T) {
+	const decls = `
+type (
+	Bytes  []byte
+	MyByte byte
+)
+`
+	for _, typ := range []string{"[]byte", "[]uint8", "Bytes", "[]MyByte", "[]int", "[]rune", "[]string"} {
+		src := fmt.Sprintf("package p\n%s\nvar s %s\nvar _ = append(s, \"x\"...)\n", decls, typ)
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "p.go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conf := types.Config{Error: func(error) {}}
+		pkg, err := conf.Check("p", fset, []*ast.File{f}, nil)
+		valid := err == nil
+
+		elem, ok := AppendElemType(pkg.Scope().Lookup("s").Type())
+		if !ok {
+			t.Fatalf("AppendElemType(%s) failed", typ)
+		}
+		if got := types.Identical(elem, types.Typ[types.Byte]); got != valid {
+			t.Errorf("%s: element type %s identical to byte = %t, but string spread valid = %t",
+				typ, elem, got, valid)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+// This file defines utilities for classifying conversions T(x).
+
+import (
+	"go/types"
+
+	"github.com/adityasaky/go-tools/internal/typeparams"
+)
+
+// ConversionAllocates reports whether a conversion from type from to
+// type to copies its operand into newly allocated memory.
+//
+// It classifies only conversions between string types and slices of
+// bytes or runes, which it reports as allocating, and reports false for
+// all other conversions. In particular, it does not cover conversions
+// that box a concrete value in an interface (see IsInterfaceConversion)
+// or conversions from an integer to a string, both of which generally
+// allocate too. The result is meaningful only if from is convertible
+// to to.
+func ConversionAllocates(from, to types.Type) bool {
+	return isString(from) && isBytesOrRunes(to) ||
+		isBytesOrRunes(from) && isString(to)
+}
+
+// IsInterfaceConversion reports whether a conversion from type from to
+// type to boxes a concrete value in an interface, as in io.Reader(f)
+// for a concrete f. Conversions between two interface types, which
+// only change the static type of an existing interface value, are not
+// boxing conversions. Neither are conversions of the untyped nil value,
+// which yield a nil interface value, nor conversions from an invalid
+// type.
+//
+// A type parameter is not an interface type, even though its underlying
+// type is its constraint: converting a value of type parameter type to
+// an interface boxes it, and converting to a type parameter never does.
+func IsInterfaceConversion(from, to types.Type) bool {
+	if b, ok := from.(*types.Basic); ok && (b.Kind() == types.UntypedNil || b.Kind() == types.Invalid) {
+		return false
+	}
+	return isInterface(to) && !isInterface(from)
+}
+
+// MutuallyConvertible reports whether a value of type a is convertible
+// to type b and a value of type b is convertible to type a.
+//
+// Note that a conversion may be legal in one direction only: an integer
+// may be converted to a string, but not vice versa.
+func MutuallyConvertible(a, b types.Type) bool {
+	return types.ConvertibleTo(a, b) && types.ConvertibleTo(b, a)
+}
+
+// ConversionMinVersion returns the earliest Go language version, such as
+// "go1.17", that permits a conversion from type from to type to.
+// It returns "go1" for conversions valid in every version.
+// The result is meaningful only if from is convertible to to.
+func ConversionMinVersion(from, to types.Type) string {
+	if s, ok := from.Underlying().(*types.Slice); ok {
+		switch to := to.Underlying().(type) {
+		case *types.Array:
+			if types.Identical(s.Elem(), to.Elem()) {
+				return "go1.20" // slice to array
+			}
+		case *types.Pointer:
+			if a, ok := to.Elem().Underlying().(*types.Array); ok && types.Identical(s.Elem(), a.Elem()) {
+				return "go1.17" // slice to array pointer
+			}
+		}
+	}
+	return "go1"
+}
+
+// ConversionChangesMethods reports whether a conversion from type from
+// to type to yields a value whose method set differs from that of its
+// operand. For example, given "type A B", converting a B to an A drops
+// the methods of B and adds those declared for A.
+func ConversionChangesMethods(from, to types.Type) bool {
+	fromMset := types.NewMethodSet(from)
+	toMset := types.NewMethodSet(to)
+	if fromMset.Len() != toMset.Len() {
+		return true
+	}
+	for i, n := 0, fromMset.Len(); i < n; i++ {
+		m := fromMset.At(i).Obj()
+		if sel := toMset.Lookup(m.Pkg(), m.Name()); sel == nil || sel.Obj() != m {
+			return true
+		}
+	}
+	return false
+}
+
+// isInterface reports whether T is an interface type, excluding type
+// parameters.
+func isInterface(T types.Type) bool {
+	return types.IsInterface(T) && !typeparams.IsTypeParam(T)
+}
+
+// isString reports whether T's underlying type is a string type.
+func isString(T types.Type) bool {
+	b, ok := T.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsString != 0
+}
+
+// isBytesOrRunes reports whether T's underlying type is a slice
+// whose element type is byte or rune.
+func isBytesOrRunes(T types.Type) bool {
+	if s, ok := T.Underlying().(*types.Slice); ok {
+		if b, ok := s.Elem().Underlying().(*types.Basic); ok {
+			return b.Kind() == types.Byte || b.Kind() == types.Rune
+		}
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+// typecheck parses and type-checks the single-file package src.
+func typecheck(t *testing.T, src string) *types.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var conf types.Config
+	pkg, err := conf.Check("p", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+// lookupType returns the type of the package-level object named name,
+// or the predeclared type of that name if pkg declares no such object.
+func lookupType(t *testing.T, pkg *types.Package, name string) types.Type {
+	t.Helper()
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		obj = types.Universe.Lookup(name)
+	}
+	if obj == nil {
+		t.Fatalf("no object %s", name)
+	}
+	return obj.Type()
+}
+
+const conversionSrc = `package p
+
+type (
+	S      string
+	B      []byte
+	MyByte byte
+	MB     []MyByte
+	P      *int
+	Reader interface{ Read([]byte) (int, error) }
+	RC     interface {
+		Reader
+		Close() error
+	}
+	File   struct{}
+	Any    interface{}
+)
+
+func (File) Read([]byte) (int, error) { return 0, nil }
+func (File) Close() error             { return nil }
+
+type (
+	Celsius    float64
+	Fahrenheit float64
+	Temp       Celsius
+	Plain      float64
+	MyReader   Reader
+)
+
+func (Celsius) String() string    { return "" }
+func (Fahrenheit) String() string { return "" }
+
+var (
+	bytes []byte
+	runes []rune
+	ints  []int
+	ptr   *int
+	arr   [4]int
+	parr  *[4]int
+	pbarr *[4]byte
+)
+`
+
+func TestConversionAllocates(t *testing.T) {
+	pkg := typecheck(t, conversionSrc)
+	for _, test := range []struct {
+		from, to string
+		want     bool
+	}{
+		{"string", "bytes", true},
+		{"bytes", "string", true},
+		{"string", "runes", true},
+		{"runes", "string", true},
+		{"S", "B", true},
+		{"MB", "S", true},
+		{"string", "S", false},
+		{"int", "float64", false},
+		{"int32", "int8", false},
+		{"ptr", "P", false},
+		{"int", "string", false},
+		{"ints", "string", false},
+
+		// Interface boxing is out of scope, even though it may allocate.
+		{"File", "Reader", false},
+	} {
+		from := lookupType(t, pkg, test.from)
+		to := lookupType(t, pkg, test.to)
+		if got := ConversionAllocates(from, to); got != test.want {
+			t.Errorf("ConversionAllocates(%s, %s) = %t, want %t", from, to, got, test.want)
+		}
+	}
+}
+
+func TestIsInterfaceConversion(t *testing.T) {
+	pkg := typecheck(t, conversionSrc)
+	for _, test := range []struct {
+		from, to string
+		want     bool
+	}{
+		{"File", "Reader", true},
+		{"File", "RC", true},
+		{"int", "Any", true},
+		{"ptr", "Any", true},
+		{"RC", "Reader", false},
+		{"Reader", "RC", false},
+		{"Reader", "Any", false},
+		{"int", "int64", false},
+		{"string", "bytes", false},
+		{"nil", "Reader", false}, // untyped nil
+	} {
+		from := lookupType(t, pkg, test.from)
+		to := lookupType(t, pkg, test.to)
+		if got := IsInterfaceConversion(from, to); got != test.want {
+			t.Errorf("IsInterfaceConversion(%s, %s) = %t, want %t", from, to, got, test.want)
+		}
+	}
+	if IsInterfaceConversion(types.Typ[types.Invalid], lookupType(t, pkg, "Reader")) {
+		t.Errorf("IsInterfaceConversion(invalid type, Reader) = true, want false")
+	}
+}
+
+func TestMutuallyConvertible(t *testing.T) {
+	pkg := typecheck(t, conversionSrc)
+	for _, test := range []struct {
+		a, b string
+		want bool
+	}{
+		{"int", "int32", true},
+		{"float64", "int", true},
+		{"string", "bytes", true},
+		{"S", "runes", true},
+		{"File", "Reader", false}, // interface-to-concrete needs an assertion
+		{"int", "string", false},  // string(int) is legal, int(string) is not
+		{"ints", "string", false},
+		{"bool", "int", false},
+	} {
+		a := lookupType(t, pkg, test.a)
+		b := lookupType(t, pkg, test.b)
+		if got := MutuallyConvertible(a, b); got != test.want {
+			t.Errorf("MutuallyConvertible(%s, %s) = %t, want %t", a, b, got, test.want)
+		}
+		if got := MutuallyConvertible(b, a); got != test.want {
+			t.Errorf("MutuallyConvertible(%s, %s) = %t, want %t", b, a, got, test.want)
+		}
+	}
+}
+
+func TestConversionMinVersion(t *testing.T) {
+	pkg := typecheck(t, conversionSrc)
+	for _, test := range []struct {
+		from, to string
+		want     string
+	}{
+		{"ints", "parr", "go1.17"},
+		{"ints", "arr", "go1.20"},
+		{"bytes", "pbarr", "go1.17"},
+		{"int", "float64", "go1"},
+		{"string", "bytes", "go1"},
+		{"File", "Reader", "go1"},
+	} {
+		from := lookupType(t, pkg, test.from)
+		to := lookupType(t, pkg, test.to)
+		if got := ConversionMinVersion(from, to); got != test.want {
+			t.Errorf("ConversionMinVersion(%s, %s) = %s, want %s", from, to, got, test.want)
+		}
+	}
+}
+
+func TestConversionChangesMethods(t *testing.T) {
+	pkg := typecheck(t, conversionSrc)
+	for _, test := range []struct {
+		from, to string
+		want     bool
+	}{
+		{"Celsius", "Fahrenheit", true}, // same method names, different methods
+		{"Celsius", "Temp", true},       // Temp does not inherit Celsius's methods
+		{"Celsius", "float64", true},
+		{"float64", "Plain", false},
+		{"int", "int64", false},
+		{"Reader", "MyReader", false},
+		{"RC", "Reader", true},
+		{"File", "Reader", true},
+	} {
+		from := lookupType(t, pkg, test.from)
+		to := lookupType(t, pkg, test.to)
+		if got := ConversionChangesMethods(from, to); got != test.want {
+			t.Errorf("ConversionChangesMethods(%s, %s) = %t, want %t", from, to, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+// This file defines utilities for querying the methods of a type.
+
+import "go/types"
+
+// PointerReceiverMethods returns the methods of *T that are not in the
+// method set of T itself, in method set order. Such methods have (or
+// are promoted through an embedded field with) a pointer receiver, and
+// may be called only on an addressable value of type T.
+//
+// Unexported methods are included only if they belong to pkg.
+// The result is empty if T is an interface or pointer type.
+func PointerReceiverMethods(T types.Type, pkg *types.Package) []*types.Func {
+	if types.IsInterface(T) {
+		return nil
+	}
+	if _, ok := T.Underlying().(*types.Pointer); ok {
+		return nil
+	}
+	var methods []*types.Func
+	mset := types.NewMethodSet(T)
+	pmset := types.NewMethodSet(types.NewPointer(T))
+	for i, n := 0, pmset.Len(); i < n; i++ {
+		m := pmset.At(i).Obj().(*types.Func)
+		if !m.Exported() && m.Pkg() != pkg {
+			continue
+		}
+		if mset.Lookup(m.Pkg(), m.Name()) == nil {
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+// SelectorStableUnderPointer reports whether the selector x.name, as
+// seen from package pkg, denotes the same field or method whether x has
+// type T or *T. It is intended for refactorings that change a type T
+// to *T.
+//
+// Method sets, not addressability, determine the result: a method with
+// a pointer receiver is in the method set of *T but not of T, so its
+// selector is unstable. A selector that resolves under neither type is
+// considered stable.
+func SelectorStableUnderPointer(T types.Type, pkg *types.Package, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(T, false, pkg, name)
+	pobj, _, _ := types.LookupFieldOrMethod(types.NewPointer(T), false, pkg, name)
+	return obj == pobj
+}
@@ -0,0 +1,78 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import (
+	"fmt"
+	"go/types"
+	"testing"
+)
+
+const methodsSrc = `package p
+
+type T struct{ *E }
+
+func (T) Value()   {}
+func (*T) Ptr()    {}
+func (*T) ptr()    {}
+
+type E struct{}
+
+func (E) EValue() {}
+func (*E) EPtr()  {}
+
+type U struct{ E }
+
+type I interface{ M() }
+`
+
+func TestPointerReceiverMethods(t *testing.T) {
+	pkg := typecheck(t, methodsSrc)
+	other := types.NewPackage("q", "q")
+	for _, test := range []struct {
+		T    types.Type
+		pkg  *types.Package
+		want string
+	}{
+		{lookupType(t, pkg, "T"), pkg, "[Ptr ptr]"},
+		{lookupType(t, pkg, "T"), other, "[Ptr]"},
+		{lookupType(t, pkg, "E"), pkg, "[EPtr]"},
+		{lookupType(t, pkg, "U"), pkg, "[EPtr]"},
+		{types.NewPointer(lookupType(t, pkg, "T")), pkg, "[]"},
+		{lookupType(t, pkg, "I"), pkg, "[]"},
+		{lookupType(t, pkg, "int"), pkg, "[]"},
+	} {
+		var names []string
+		for _, m := range PointerReceiverMethods(test.T, test.pkg) {
+			names = append(names, m.Name())
+		}
+		if got := fmt.Sprint(names); got != test.want {
+			t.Errorf("PointerReceiverMethods(%s, %s) = %s, want %s", test.T, test.pkg.Path(), got, test.want)
+		}
+	}
+}
+
+func TestSelectorStableUnderPointer(t *testing.T) {
+	pkg := typecheck(t, methodsSrc)
+	T := lookupType(t, pkg, "T")
+	for _, test := range []struct {
+		T    types.Type
+		name string
+		want bool
+	}{
+		{T, "Value", true},
+		{T, "Ptr", false},
+		{T, "E", true},    // field
+		{T, "EPtr", true}, // promoted through *E
+		{T, "Missing", true},
+		{lookupType(t, pkg, "U"), "EValue", true},
+		{lookupType(t, pkg, "U"), "EPtr", false},
+		{lookupType(t, pkg, "I"), "M", false}, // *I has no methods
+	} {
+		if got := SelectorStableUnderPointer(test.T, pkg, test.name); got != test.want {
+			t.Errorf("SelectorStableUnderPointer(%s, %s) = %t, want %t", test.T, test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+// This file defines predicates over types.
+
+import "go/types"
+
+// IsBasic reports whether the underlying type of T is a basic type,
+// and if so, returns its kind. For example, it reports (Int, true)
+// both for int and for a defined type T whose underlying type is int.
+// It reports (Invalid, false) for the invalid type.
+func IsBasic(T types.Type) (types.BasicKind, bool) {
+	if b, ok := T.Underlying().(*types.Basic); ok && b.Kind() != types.Invalid {
+		return b.Kind(), true
+	}
+	return types.Invalid, false
+}
+
+// ZeroValueSafe reports whether the zero value of type T is likely to
+// be usable as a method receiver without a nil dereference, as in
+// "var x T; x.M()".
+//
+// It is a heuristic: the zero value of a pointer, map, slice, channel,
+// function, interface or unsafe.Pointer type is nil, so it reports
+// false for those, and true for all other types. It does not examine
+// the methods of T or the fields of structs.
+//
+// A type parameter is treated like an interface and reported unsafe,
+// even if its constraint, such as ~int, admits only types whose zero
+// value is safe: its type set is not examined.
+func ZeroValueSafe(T types.Type) bool {
+	switch T := T.Underlying().(type) {
+	case *types.Pointer, *types.Map, *types.Slice, *types.Chan, *types.Signature, *types.Interface:
+		return false
+	case *types.Basic:
+		return T.Kind() != types.UnsafePointer && T.Kind() != types.UntypedNil
+	}
+	return true
+}
+
+// PointerBase reports whether the underlying type of T is a pointer
+// type, and if so, returns the type it points to. Only one level of
+// indirection is removed: the base of **T is *T.
+func PointerBase(T types.Type) (types.Type, bool) {
+	if ptr, ok := T.Underlying().(*types.Pointer); ok {
+		return ptr.Elem(), true
+	}
+	return nil, false
+}
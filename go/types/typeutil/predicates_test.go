@@ -0,0 +1,103 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import (
+	"go/types"
+	"testing"
+)
+
+const predicatesSrc = `package p
+
+type (
+	T int
+	S struct{ x int }
+	P *int
+	M map[string]int
+	I interface{ M() }
+	F func()
+	C chan int
+	A [2]*int
+)
+`
+
+func TestIsBasic(t *testing.T) {
+	pkg := typecheck(t, predicatesSrc)
+	for _, test := range []struct {
+		name     string
+		wantKind types.BasicKind
+		wantOk   bool
+	}{
+		{"int", types.Int, true},
+		{"string", types.String, true},
+		{"bool", types.Bool, true},
+		{"T", types.Int, true},
+		{"S", types.Invalid, false},
+		{"P", types.Invalid, false},
+		{"error", types.Invalid, false},
+	} {
+		kind, ok := IsBasic(lookupType(t, pkg, test.name))
+		if kind != test.wantKind || ok != test.wantOk {
+			t.Errorf("IsBasic(%s) = (%v, %t), want (%v, %t)",
+				test.name, kind, ok, test.wantKind, test.wantOk)
+		}
+	}
+	if kind, ok := IsBasic(types.Typ[types.Invalid]); kind != types.Invalid || ok {
+		t.Errorf("IsBasic(invalid type) = (%v, %t), want (%v, false)", kind, ok, types.Invalid)
+	}
+}
+
+func TestZeroValueSafe(t *testing.T) {
+	pkg := typecheck(t, predicatesSrc)
+	for _, test := range []struct {
+		T    types.Type
+		want bool
+	}{
+		{lookupType(t, pkg, "int"), true},
+		{lookupType(t, pkg, "string"), true},
+		{lookupType(t, pkg, "T"), true},
+		{lookupType(t, pkg, "S"), true},
+		{lookupType(t, pkg, "A"), true},
+		{lookupType(t, pkg, "P"), false},
+		{lookupType(t, pkg, "M"), false},
+		{lookupType(t, pkg, "I"), false},
+		{lookupType(t, pkg, "F"), false},
+		{lookupType(t, pkg, "C"), false},
+		{lookupType(t, pkg, "error"), false},
+		{types.NewSlice(types.Typ[types.Int]), false},
+		{types.Typ[types.UnsafePointer], false},
+	} {
+		if got := ZeroValueSafe(test.T); got != test.want {
+			t.Errorf("ZeroValueSafe(%s) = %t, want %t", test.T, got, test.want)
+		}
+	}
+}
+
+func TestPointerBase(t *testing.T) {
+	pkg := typecheck(t, predicatesSrc)
+	T := lookupType(t, pkg, "T")
+	for _, test := range []struct {
+		T      types.Type
+		want   string
+		wantOk bool
+	}{
+		{types.NewPointer(types.Typ[types.Int]), "int", true},
+		{types.NewPointer(types.NewPointer(T)), "*p.T", true},
+		{lookupType(t, pkg, "P"), "int", true},
+		{T, "", false},
+		{lookupType(t, pkg, "M"), "", false},
+		{types.Typ[types.UnsafePointer], "", false},
+	} {
+		base, ok := PointerBase(test.T)
+		var got string
+		if base != nil {
+			got = base.String()
+		}
+		if got != test.want || ok != test.wantOk {
+			t.Errorf("PointerBase(%s) = (%s, %t), want (%s, %t)",
+				test.T, got, ok, test.want, test.wantOk)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"math"
+)
+
+// Representable reports whether the constant x can be represented by
+// a value of type T, following the rules of the Go spec for
+// representability. It reports false if T is not a basic type.
+//
+// The sizes of int, uint and uintptr are taken from sizes; if sizes is
+// nil, those of gc on amd64 are assumed.
+func Representable(x constant.Value, T types.Type, sizes types.Sizes) bool {
+	b, ok := T.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	info := b.Info()
+	untyped := info&types.IsUntyped != 0
+	switch {
+	case info&types.IsInteger != 0:
+		x := constant.ToInt(x)
+		if x.Kind() != constant.Int {
+			return false
+		}
+		if untyped {
+			return true
+		}
+		if sizes == nil {
+			sizes = types.SizesFor("gc", "amd64")
+		}
+		bits := uint(8 * sizes.Sizeof(b))
+		if info&types.IsUnsigned != 0 {
+			return constant.Sign(x) >= 0 && constant.BitLen(x) <= int(bits)
+		}
+		min := constant.Shift(constant.MakeInt64(-1), token.SHL, bits-1)
+		max := constant.Shift(constant.MakeInt64(1), token.SHL, bits-1)
+		return constant.Compare(x, token.GEQ, min) && constant.Compare(x, token.LSS, max)
+
+	case info&types.IsFloat != 0:
+		x := constant.ToFloat(x)
+		if x.Kind() != constant.Float && x.Kind() != constant.Int {
+			return false
+		}
+		return untyped || fitsFloat(x, b.Kind() == types.Float32)
+
+	case info&types.IsComplex != 0:
+		x := constant.ToComplex(x)
+		if x.Kind() != constant.Complex {
+			return false
+		}
+		single := b.Kind() == types.Complex64
+		return untyped || fitsFloat(constant.Real(x), single) && fitsFloat(constant.Imag(x), single)
+
+	case info&types.IsString != 0:
+		return x.Kind() == constant.String
+
+	case info&types.IsBoolean != 0:
+		return x.Kind() == constant.Bool
+	}
+	return false
+}
+
+// fitsFloat reports whether the numeric constant x is within the range
+// of float32 (if single is set) or float64.
+func fitsFloat(x constant.Value, single bool) bool {
+	if single {
+		f, _ := constant.Float32Val(x)
+		return !math.IsInf(float64(f), 0)
+	}
+	f, _ := constant.Float64Val(x)
+	return !math.IsInf(f, 0)
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestRepresentable(t *testing.T) {
+	sizes32 := types.SizesFor("gc", "386")
+	for _, test := range []struct {
+		lit   string
+		kind  token.Token
+		typ   types.BasicKind
+		sizes types.Sizes
+		want  bool
+	}{
+		// integers
+		{"255", token.INT, types.Uint8, nil, true},
+		{"256", token.INT, types.Uint8, nil, false},
+		{"-1", token.INT, types.Uint, nil, false},
+		{"-128", token.INT, types.Int8, nil, true},
+		{"-129", token.INT, types.Int8, nil, false},
+		{"127", token.INT, types.Int8, nil, true},
+		{"128", token.INT, types.Int8, nil, false},
+		{"1e3", token.FLOAT, types.Int16, nil, true},
+		{"1.5", token.FLOAT, types.Int, nil, false},
+		{"4294967296", token.INT, types.Int, nil, true},
+		{"4294967296", token.INT, types.Int, sizes32, false},
+		{"1e100", token.FLOAT, types.UntypedInt, nil, true},
+
+		// floats
+		{"1e38", token.FLOAT, types.Float32, nil, true},
+		{"1e39", token.FLOAT, types.Float32, nil, false},
+		{"1e39", token.FLOAT, types.Float64, nil, true},
+		{"1e309", token.FLOAT, types.Float64, nil, false},
+		{"1e309", token.FLOAT, types.UntypedFloat, nil, true},
+		{"1", token.INT, types.Float64, nil, true},
+		{"1i", token.IMAG, types.Float64, nil, false},
+
+		// complex numbers
+		{"1i", token.IMAG, types.Complex64, nil, true},
+		{"1e39", token.FLOAT, types.Complex64, nil, false},
+		{"1e39", token.FLOAT, types.Complex128, nil, true},
+		{"2", token.INT, types.Complex128, nil, true},
+
+		// others
+		{`"x"`, token.STRING, types.String, nil, true},
+		{`"x"`, token.STRING, types.Int, nil, false},
+		{"1", token.INT, types.String, nil, false},
+	} {
+		x := constant.MakeFromLiteral(test.lit, test.kind, 0)
+		if test.lit[0] == '-' {
+			x = constant.UnaryOp(token.SUB, constant.MakeFromLiteral(test.lit[1:], test.kind, 0), 0)
+		}
+		typ := types.Typ[test.typ]
+		if got := Representable(x, typ, test.sizes); got != test.want {
+			t.Errorf("Representable(%s, %s) = %t, want %t", test.lit, typ, got, test.want)
+		}
+	}
+
+	if Representable(constant.MakeBool(true), types.NewSlice(types.Typ[types.Bool]), nil) {
+		t.Errorf("Representable(true, []bool) = true, want false")
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import "go/types"
+
+// CallableObjects returns the exported package-level functions of pkg,
+// sorted by name. Methods are not included, as they are not members of
+// the package scope.
+//
+// It is intended for completion after a qualifier such as "pkg.".
+func CallableObjects(pkg *types.Package) []*types.Func {
+	var funcs []*types.Func
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		if fn, ok := scope.Lookup(name).(*types.Func); ok && fn.Exported() {
+			funcs = append(funcs, fn)
+		}
+	}
+	return funcs
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestCallableObjects(t *testing.T) {
+	const src = `package p
+
+import "errors"
+
+var _ = errors.New
+
+type T int
+
+func (T) Method() {}
+
+func Exported() {}
+func unexported() {}
+
+var Var = func() {}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("p", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(funcs []*types.Func) []string {
+		var names []string
+		for _, fn := range funcs {
+			names = append(names, fn.Name())
+		}
+		return names
+	}
+	if got, want := fmt.Sprint(names(CallableObjects(pkg))), "[Exported]"; got != want {
+		t.Errorf("CallableObjects(p) = %s, want %s", got, want)
+	}
+
+	// Check an imported package too.
+	errorsPkg := pkg.Imports()[0]
+	got := make(map[string]bool)
+	for _, name := range names(CallableObjects(errorsPkg)) {
+		got[name] = true
+	}
+	for _, name := range []string{"As", "Is", "New", "Unwrap"} {
+		if !got[name] {
+			t.Errorf("CallableObjects(errors) lacks %s", name)
+		}
+	}
+	if got["Error"] {
+		t.Errorf("CallableObjects(errors) contains method Error")
+	}
+}
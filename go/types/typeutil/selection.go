@@ -0,0 +1,77 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+// This file defines utilities for inspecting selections x.f.
+
+import "go/types"
+
+// ThroughEmbeddedPointer reports whether the field or method denoted by
+// sel is promoted through at least one embedded field of pointer type,
+// as in x.E.M where E is declared as *E within the struct type of x.
+// Evaluating such a selector panics at run time if that field is nil.
+//
+// Unlike sel.Indirect(), it does not consider the type of x itself.
+func ThroughEmbeddedPointer(sel *types.Selection) bool {
+	T := sel.Recv()
+	index := sel.Index()
+	for _, i := range index[:len(index)-1] {
+		if ptr, ok := T.Underlying().(*types.Pointer); ok {
+			T = ptr.Elem()
+		}
+		s, ok := T.Underlying().(*types.Struct)
+		if !ok {
+			break
+		}
+		T = s.Field(i).Type()
+		if _, ok := T.Underlying().(*types.Pointer); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DerefsOperand reports whether evaluating the selection sel implicitly
+// dereferences its operand x, as in p.F for a p of type *T, or p.M for
+// a method M of T with a value receiver. Indirections through embedded
+// pointer fields are reported by ThroughEmbeddedPointer.
+//
+// It reports false for a method expression such as (*T).M, which has no
+// operand.
+func DerefsOperand(sel *types.Selection) bool {
+	if sel.Kind() == types.MethodExpr {
+		return false
+	}
+	if _, ok := sel.Recv().Underlying().(*types.Pointer); !ok {
+		return false
+	}
+	if sel.Kind() == types.FieldVal || len(sel.Index()) > 1 {
+		return true
+	}
+	recv := sel.Obj().Type().(*types.Signature).Recv()
+	_, ptr := recv.Type().(*types.Pointer)
+	return !ptr
+}
+
+// TakesOperandAddress reports whether the method selection sel
+// implicitly takes the address of its operand x, that is, whether x.m
+// is shorthand for (&x).m because m has a pointer receiver but x is
+// not a pointer. Such an operand must be addressable.
+//
+// It reports false if the method is reached through an embedded
+// pointer field, since the receiver is then loaded from that field.
+func TakesOperandAddress(sel *types.Selection) bool {
+	if sel.Kind() != types.MethodVal || types.IsInterface(sel.Recv()) {
+		return false
+	}
+	if _, ok := sel.Recv().Underlying().(*types.Pointer); ok {
+		return false
+	}
+	recv := sel.Obj().Type().(*types.Signature).Recv()
+	if _, ok := recv.Type().(*types.Pointer); !ok {
+		return false
+	}
+	return !ThroughEmbeddedPointer(sel)
+}
@@ -0,0 +1,180 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+// checkSelections type-checks the single-file package src and returns
+// its selections, keyed by the source text of each selector expression.
+func checkSelections(t *testing.T, src string) map[string]*types.Selection {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{Selections: make(map[*ast.SelectorExpr]*types.Selection)}
+	var conf types.Config
+	if _, err := conf.Check("p", fset, []*ast.File{f}, info); err != nil {
+		t.Fatal(err)
+	}
+	selections := make(map[string]*types.Selection)
+	for e, sel := range info.Selections {
+		selections[src[fset.Position(e.Pos()).Offset:fset.Position(e.End()).Offset]] = sel
+	}
+	return selections
+}
+
+func TestThroughEmbeddedPointer(t *testing.T) {
+	selections := checkSelections(t, `package p
+
+type Inner struct{ F int }
+
+func (*Inner) M() {}
+
+type ByValue struct{ Inner }
+type ByPointer struct{ *Inner }
+type Deep struct{ ByPointer }
+type Namer interface{ Name() string }
+type Iface struct{ Namer }
+
+func _(v ByValue, pv *ByValue, p ByPointer, d Deep, i Iface, in *Inner) {
+	_ = v.F
+	_ = pv.F
+	_ = p.F
+	_ = d.F
+	_ = in.F
+	v.M()
+	pv.M()
+	p.M()
+	d.M()
+	i.Name()
+}
+`)
+	for expr, want := range map[string]bool{
+		"v.F":    false,
+		"pv.F":   false,
+		"p.F":    true,
+		"d.F":    true,
+		"in.F":   false,
+		"v.M":    false,
+		"pv.M":   false,
+		"p.M":    true,
+		"d.M":    true,
+		"i.Name": false,
+	} {
+		sel, ok := selections[expr]
+		if !ok {
+			t.Errorf("no selection for %s", expr)
+			continue
+		}
+		if got := ThroughEmbeddedPointer(sel); got != want {
+			t.Errorf("ThroughEmbeddedPointer(%s) = %t, want %t", expr, got, want)
+		}
+	}
+}
+
+func TestDerefsOperand(t *testing.T) {
+	selections := checkSelections(t, `package p
+
+type T struct {
+	F int
+	E
+}
+
+func (T) Value() {}
+func (*T) Ptr()  {}
+
+type E struct{ G int }
+
+func (E) EValue() {}
+
+func _(v T, p *T) {
+	_ = v.F
+	_ = p.F
+	_ = p.G
+	v.Value()
+	p.Value()
+	p.Ptr()
+	p.EValue()
+	_ = (*T).Value
+	_ = (*T).Ptr
+}
+`)
+	for expr, want := range map[string]bool{
+		"v.F":        false,
+		"p.F":        true,
+		"p.G":        true,
+		"v.Value":    false,
+		"p.Value":    true,
+		"p.Ptr":      false,
+		"p.EValue":   true,
+		"(*T).Value": false, // method expression: no operand
+		"(*T).Ptr":   false,
+	} {
+		sel, ok := selections[expr]
+		if !ok {
+			t.Errorf("no selection for %s", expr)
+			continue
+		}
+		if got := DerefsOperand(sel); got != want {
+			t.Errorf("DerefsOperand(%s) = %t, want %t", expr, got, want)
+		}
+	}
+}
+
+func TestTakesOperandAddress(t *testing.T) {
+	selections := checkSelections(t, `package p
+
+type T struct{ E }
+
+func (T) Value() {}
+func (*T) Ptr()  {}
+
+type E struct{}
+
+func (*E) EPtr() {}
+
+type U struct{ *E }
+
+type I interface{ M() }
+
+func _(v T, p *T, u U, i I) {
+	v.Value()
+	v.Ptr()
+	v.EPtr()
+	p.Ptr()
+	p.EPtr()
+	u.EPtr()
+	i.M()
+	_ = (*T).Ptr
+}
+`)
+	for expr, want := range map[string]bool{
+		"v.Value":  false,
+		"v.Ptr":    true,
+		"v.EPtr":   true,
+		"p.Ptr":    false,
+		"p.EPtr":   false,
+		"u.EPtr":   false,
+		"i.M":      false,
+		"(*T).Ptr": false,
+	} {
+		sel, ok := selections[expr]
+		if !ok {
+			t.Errorf("no selection for %s", expr)
+			continue
+		}
+		if got := TakesOperandAddress(sel); got != want {
+			t.Errorf("TakesOperandAddress(%s) = %t, want %t", expr, got, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+// This file defines utilities for querying function signatures.
+
+import "go/types"
+
+// CallableSignature reports whether a value of type T may be called,
+// that is, whether the underlying type of T is a function type, and if
+// so, returns that signature. For a defined type such as
+// "type F func(int)", it returns the signature of the underlying
+// func(int).
+func CallableSignature(T types.Type) (*types.Signature, bool) {
+	sig, ok := T.Underlying().(*types.Signature)
+	return sig, ok
+}
+
+// MethodValueAssignableTo reports whether the method value x.m, for an
+// addressable x of type recv, is assignable to a variable of function
+// type target, such as a callback parameter.
+//
+// It reports false if m is not a method of recv, or of *recv.
+func MethodValueAssignableTo(recv types.Type, m *types.Func, target *types.Signature) bool {
+	obj, _, _ := types.LookupFieldOrMethod(recv, true, m.Pkg(), m.Name())
+	if obj != m {
+		return false
+	}
+	sig := m.Type().(*types.Signature)
+	bound := types.NewSignature(nil, sig.Params(), sig.Results(), sig.Variadic())
+	return types.AssignableTo(bound, target)
+}
+
+// ParamNames returns the names of the parameters of sig, in order.
+// Unnamed parameters have an empty name; blank parameters are named "_".
+//
+// It is intended for signature help that displays parameter names.
+func ParamNames(sig *types.Signature) []string {
+	params := sig.Params()
+	names := make([]string, params.Len())
+	for i := range names {
+		names[i] = params.At(i).Name()
+	}
+	return names
+}
+
+// AcceptsSpread reports whether, in a call of a function of type sig
+// with argCount arguments, the argument at index argIndex may be
+// followed by "..." to pass a slice as the variadic parameter.
+//
+// This holds only for the last argument of a call to a variadic
+// function that supplies exactly one argument per parameter.
+func AcceptsSpread(sig *types.Signature, argIndex, argCount int) bool {
+	return sig.Variadic() && argCount == sig.Params().Len() && argIndex == argCount-1
+}
+
+// CallableWithNoArgs reports whether a function of type sig may be
+// called without arguments, that is, whether it has no parameters or
+// only a variadic one.
+func CallableWithNoArgs(sig *types.Signature) bool {
+	n := sig.Params().Len()
+	return n == 0 || n == 1 && sig.Variadic()
+}
+
+// ResultTypes returns the types of the values produced by an expression
+// of type T, such as the type recorded for a call in types.Info.Types.
+// The result is empty if T is an empty tuple (a call of a function
+// without results), the components of T if it is a tuple, and T itself
+// otherwise.
+func ResultTypes(T types.Type) []types.Type {
+	tuple, ok := T.(*types.Tuple)
+	if !ok {
+		return []types.Type{T}
+	}
+	results := make([]types.Type, tuple.Len())
+	for i := range results {
+		results[i] = tuple.At(i).Type()
+	}
+	return results
+}
@@ -0,0 +1,222 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+const signatureSrc = `package p
+
+type T struct{ E }
+
+func (T) Handle(int) error { return nil }
+func (*T) Close() error    { return nil }
+
+type E struct{}
+
+func (E) Log(string, ...interface{}) {}
+
+type U struct{}
+
+func (U) Handle(int) error { return nil }
+
+type Handler func(int) error
+
+var (
+	handler  func(int) error
+	closer   func() error
+	logger   func(string, ...interface{})
+	notifier func(int)
+)
+`
+
+func TestMethodValueAssignableTo(t *testing.T) {
+	pkg := typecheck(t, signatureSrc)
+	T := lookupType(t, pkg, "T")
+	method := func(recv types.Type, name string) *types.Func {
+		obj, _, _ := types.LookupFieldOrMethod(recv, true, pkg, name)
+		return obj.(*types.Func)
+	}
+	signature := func(name string) *types.Signature {
+		return lookupType(t, pkg, name).Underlying().(*types.Signature)
+	}
+
+	for _, test := range []struct {
+		recv   types.Type
+		method *types.Func
+		target string
+		want   bool
+	}{
+		{T, method(T, "Handle"), "handler", true},
+		{T, method(T, "Handle"), "Handler", true},
+		{T, method(T, "Close"), "closer", true},
+		{T, method(T, "Log"), "logger", true},
+		{T, method(T, "Handle"), "notifier", false},
+		{T, method(T, "Close"), "handler", false},
+		{T, method(lookupType(t, pkg, "U"), "Handle"), "handler", false},
+	} {
+		got := MethodValueAssignableTo(test.recv, test.method, signature(test.target))
+		if got != test.want {
+			t.Errorf("MethodValueAssignableTo(%s, %s, %s) = %t, want %t",
+				test.recv, test.method.FullName(), test.target, got, test.want)
+		}
+	}
+}
+
+func TestParamNames(t *testing.T) {
+	pkg := typecheck(t, `package p
+
+func named(a int, b, c string)
+func unnamed(int, string)
+func blank(_ int, x ...bool)
+func none()
+`)
+	for _, test := range []struct {
+		name string
+		want string
+	}{
+		{"named", `["a" "b" "c"]`},
+		{"unnamed", `["" ""]`},
+		{"blank", `["_" "x"]`},
+		{"none", "[]"},
+	} {
+		sig := lookupType(t, pkg, test.name).(*types.Signature)
+		if got := fmt.Sprintf("%q", ParamNames(sig)); got != test.want {
+			t.Errorf("ParamNames(%s) = %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestAcceptsSpread(t *testing.T) {
+	pkg := typecheck(t, `package p
+
+func variadic(string, ...int)
+func fixed(string, []int)
+`)
+	variadic := lookupType(t, pkg, "variadic").(*types.Signature)
+	fixed := lookupType(t, pkg, "fixed").(*types.Signature)
+	for _, test := range []struct {
+		sig          *types.Signature
+		index, count int
+		want         bool
+	}{
+		{variadic, 1, 2, true},  // variadic("", s...)
+		{variadic, 0, 2, false}, // variadic(s..., s)
+		{variadic, 0, 1, false}, // variadic(s...)
+		{variadic, 2, 3, false}, // variadic("", 1, s...)
+		{fixed, 1, 2, false},
+		{fixed, 0, 2, false},
+	} {
+		got := AcceptsSpread(test.sig, test.index, test.count)
+		if got != test.want {
+			t.Errorf("AcceptsSpread(%s, %d, %d) = %t, want %t",
+				test.sig, test.index, test.count, got, test.want)
+		}
+	}
+}
+
+func TestCallableWithNoArgs(t *testing.T) {
+	pkg := typecheck(t, `package p
+
+func none()
+func variadic(...int)
+func one(int)
+func leading(int, ...int)
+`)
+	for name, want := range map[string]bool{
+		"none":     true,
+		"variadic": true,
+		"one":      false,
+		"leading":  false,
+	} {
+		sig := lookupType(t, pkg, name).(*types.Signature)
+		if got := CallableWithNoArgs(sig); got != want {
+			t.Errorf("CallableWithNoArgs(%s) = %t, want %t", sig, got, want)
+		}
+	}
+}
+
+func TestResultTypes(t *testing.T) {
+	const src = `package p
+
+func none()
+func one() int
+func two() (n int, err error)
+
+func _() {
+	none()
+	one()
+	two()
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{Types: make(map[ast.Expr]types.TypeAndValue)}
+	var conf types.Config
+	if _, err := conf.Check("p", fset, []*ast.File{f}, info); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"none": "[]",
+		"one":  "[int]",
+		"two":  "[int error]",
+	}
+	for e, tv := range info.Types {
+		if call, ok := e.(*ast.CallExpr); ok {
+			name := call.Fun.(*ast.Ident).Name
+			if got := fmt.Sprint(ResultTypes(tv.Type)); got != want[name] {
+				t.Errorf("ResultTypes(%s()) = %s, want %s", name, got, want[name])
+			}
+			delete(want, name)
+		}
+	}
+	for name := range want {
+		t.Errorf("no type recorded for call to %s", name)
+	}
+}
+
+func TestCallableSignature(t *testing.T) {
+	pkg := typecheck(t, `package p
+
+type F func(int) string
+
+var (
+	fn  func(string, ...int)
+	def F
+	ptr *func()
+	num int
+)
+`)
+	for _, test := range []struct {
+		name   string
+		want   string
+		wantOk bool
+	}{
+		{"fn", "func(string, ...int)", true},
+		{"def", "func(int) string", true},
+		{"ptr", "", false}, // must be dereferenced first
+		{"num", "", false},
+	} {
+		sig, ok := CallableSignature(lookupType(t, pkg, test.name))
+		var got string
+		if sig != nil {
+			got = sig.String()
+		}
+		if got != test.want || ok != test.wantOk {
+			t.Errorf("CallableSignature(%s) = (%s, %t), want (%s, %t)",
+				test.name, got, ok, test.want, test.wantOk)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package typeutil
+
+import (
+	"go/types"
+	"testing"
+)
+
+const typeparamsSrc = `package p
+
+func f[P any](p P) {}
+func g[P ~int](p P) {}
+`
+
+// paramType returns the type of the first parameter of the function
+// named name in pkg.
+func paramType(t *testing.T, pkg *types.Package, name string) types.Type {
+	t.Helper()
+	return lookupType(t, pkg, name).(*types.Signature).Params().At(0).Type()
+}
+
+func TestIsInterfaceConversionTypeParams(t *testing.T) {
+	pkg := typecheck(t, typeparamsSrc)
+	P := paramType(t, pkg, "f")
+	any := types.NewInterfaceType(nil, nil)
+	for _, test := range []struct {
+		from, to types.Type
+		want     bool
+	}{
+		{P, any, true},                   // any(p) boxes p
+		{types.Typ[types.Int], P, false}, // P(x) is not an interface value
+	} {
+		if got := IsInterfaceConversion(test.from, test.to); got != test.want {
+			t.Errorf("IsInterfaceConversion(%s, %s) = %t, want %t", test.from, test.to, got, test.want)
+		}
+	}
+}
+
+func TestZeroValueSafeTypeParams(t *testing.T) {
+	pkg := typecheck(t, typeparamsSrc)
+	for _, name := range []string{"f", "g"} {
+		P := paramType(t, pkg, name)
+		if ZeroValueSafe(P) {
+			t.Errorf("ZeroValueSafe(%s) in %s = true, want false", P, name)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !go1.18
+// +build !go1.18
+
+package typeparams
+
+import "go/types"
+
+// NOTE: doc comments must be kept in sync with typeparams_go118.go.
+
+// Unlike the rest of this package, which targets the pre-release
+// dev.typeparams API behind the typeparams build tag, the functions in
+// this file pair are gated on the Go release that introduced
+// types.TypeParam. Clients such as go/types/typeutil must recognize type
+// parameters in any program checked by a Go 1.18+ go/types, whether or
+// not the typeparams tag is set, so these functions do not follow
+// Enabled. In a go1.17 build with the tag, type parameters of the
+// pre-release API are not recognized.
+
+// IsTypeParam reports whether t is a type parameter. The underlying type
+// of a type parameter is its constraint interface, so callers asking
+// whether a type is an interface should exclude type parameters first.
+func IsTypeParam(types.Type) bool {
+	return false
+}